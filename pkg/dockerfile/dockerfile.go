@@ -0,0 +1,87 @@
+// Package dockerfile is a minimal Dockerfile front-end, just enough to pull
+// the metadata pipelines.ImageBuild needs out of a Dockerfile without
+// shelling out to a real parser
+package dockerfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Copy is a single COPY instruction's source and destination
+type Copy struct {
+	Src string
+	Dst string
+}
+
+// Dockerfile is the subset of instructions this front-end understands:
+// FROM, ARG, ENV, LABEL, COPY, and RUN. Anything else is ignored
+type Dockerfile struct {
+	From   string
+	Args   map[string]string
+	Env    map[string]string
+	Labels map[string]string
+	Copies []Copy
+	Runs   []string
+}
+
+// Parse reads a Dockerfile from r, line by line. Line continuations (a
+// trailing "\") are not supported
+func Parse(r io.Reader) (*Dockerfile, error) {
+	df := &Dockerfile{
+		Args:   make(map[string]string),
+		Env:    make(map[string]string),
+		Labels: make(map[string]string),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		instruction, rest, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		rest = strings.TrimSpace(rest)
+
+		switch strings.ToUpper(instruction) {
+		case "FROM":
+			df.From = rest
+		case "ARG":
+			k, v := splitKeyValue(rest)
+			df.Args[k] = v
+		case "ENV":
+			k, v := splitKeyValue(rest)
+			df.Env[k] = v
+		case "LABEL":
+			k, v := splitKeyValue(rest)
+			df.Labels[k] = strings.Trim(v, `"`)
+		case "COPY":
+			fields := strings.Fields(rest)
+			if len(fields) == 2 {
+				df.Copies = append(df.Copies, Copy{Src: fields[0], Dst: fields[1]})
+			}
+		case "RUN":
+			df.Runs = append(df.Runs, rest)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan dockerfile: %w", err)
+	}
+
+	return df, nil
+}
+
+// splitKeyValue splits "KEY=value" or "KEY value" pairs, as accepted by
+// ARG, ENV, and LABEL instructions
+func splitKeyValue(s string) (key string, value string) {
+	if idx := strings.IndexAny(s, "= "); idx != -1 {
+		return s[:idx], strings.TrimSpace(s[idx+1:])
+	}
+	return s, ""
+}
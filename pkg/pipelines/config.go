@@ -0,0 +1,13 @@
+package pipelines
+
+// ArtifactConfig controls where a pipeline writes its artifacts and which
+// scanner backends it should use to produce them
+type ArtifactConfig struct {
+	Directory            string
+	ImageTarball         string
+	SBOMFilename         string
+	GrypeFilename        string
+	SBOMGeneratorBackend string
+	VulnerabilityBackend string
+	BuildBackend         string
+}
@@ -0,0 +1,149 @@
+package pipelines
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// CheckStatus is the outcome of a single Doctor preflight check
+type CheckStatus string
+
+const (
+	CheckOK   CheckStatus = "ok"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+)
+
+// CheckResult reports a single Doctor preflight check
+type CheckResult struct {
+	Name   string      `json:"name"`
+	Status CheckStatus `json:"status"`
+	Detail string      `json:"detail,omitempty"`
+}
+
+// Doctor runs environment preflight checks: container engine socket
+// reachability, artifact directory disk space and write permissions, and
+// required binaries on $PATH. It replaces the environment-probing half of
+// the old Debug pipeline
+type Doctor struct {
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// ArtifactDir is checked for free disk space and write permissions
+	ArtifactDir string
+
+	// RequiredBinaries must be resolvable on $PATH
+	RequiredBinaries []string
+
+	// MinFreeBytes is the minimum free space ArtifactDir must have for
+	// checkDiskSpace to report CheckOK instead of CheckWarn
+	MinFreeBytes uint64
+
+	logger *slog.Logger
+}
+
+// NewDoctor creates a new Doctor pipeline with the project's default
+// requirements: syft and grype on $PATH, and 100MB free in the artifact dir
+func NewDoctor(stdout io.Writer, stderr io.Writer) *Doctor {
+	return &Doctor{
+		Stdout:           stdout,
+		Stderr:           stderr,
+		ArtifactDir:      os.TempDir(),
+		RequiredBinaries: []string{"syft", "grype"},
+		MinFreeBytes:     100 * 1024 * 1024,
+		logger:           slog.Default().With("pipeline", "doctor"),
+	}
+}
+
+// WithArtifactConfig points Doctor's disk space and write permission checks
+// at config.Directory
+func (d *Doctor) WithArtifactConfig(config ArtifactConfig) *Doctor {
+	d.ArtifactDir = config.Directory
+	return d
+}
+
+// Run executes every preflight check, always returning the full report even
+// if individual checks fail, so one broken socket doesn't hide other results
+func (d *Doctor) Run() []CheckResult {
+	results := []CheckResult{
+		d.checkSocket("docker", "/var/run/docker.sock"),
+		d.checkSocket("podman", podmanSocketPath()),
+		d.checkDiskSpace(),
+		d.checkWritable(),
+	}
+	for _, bin := range d.RequiredBinaries {
+		results = append(results, d.checkBinary(bin))
+	}
+	return results
+}
+
+func (d *Doctor) checkSocket(engine string, path string) CheckResult {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		d.logger.Debug("engine socket unreachable", "engine", engine, "path", path, "error", err)
+		return CheckResult{Name: engine + "-socket", Status: CheckWarn, Detail: err.Error()}
+	}
+	conn.Close()
+	return CheckResult{Name: engine + "-socket", Status: CheckOK, Detail: path}
+}
+
+// podmanSocketPath returns the rootless podman socket under
+// $XDG_RUNTIME_DIR, which is where the default (rootless) podman install
+// exposes it, falling back to the rootful path used by system-wide installs
+func podmanSocketPath() string {
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "podman", "podman.sock")
+	}
+	return "/run/podman/podman.sock"
+}
+
+func (d *Doctor) checkDiskSpace() CheckResult {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(d.ArtifactDir, &stat); err != nil {
+		return CheckResult{Name: "disk-space", Status: CheckFail, Detail: err.Error()}
+	}
+
+	free := stat.Bavail * uint64(stat.Bsize)
+	detail := fmt.Sprintf("%d bytes free", free)
+	if free < d.MinFreeBytes {
+		return CheckResult{Name: "disk-space", Status: CheckWarn, Detail: detail}
+	}
+	return CheckResult{Name: "disk-space", Status: CheckOK, Detail: detail}
+}
+
+func (d *Doctor) checkWritable() CheckResult {
+	probe := filepath.Join(d.ArtifactDir, ".doctor-write-check")
+	f, err := os.Create(probe)
+	if err != nil {
+		return CheckResult{Name: "artifact-dir-writable", Status: CheckFail, Detail: err.Error()}
+	}
+	f.Close()
+	os.Remove(probe)
+	return CheckResult{Name: "artifact-dir-writable", Status: CheckOK, Detail: d.ArtifactDir}
+}
+
+// checkBinary reports whether bin resolves on $PATH
+func (d *Doctor) checkBinary(bin string) CheckResult {
+	path, err := searchPath(bin)
+	if err != nil {
+		return CheckResult{Name: bin, Status: CheckFail, Detail: err.Error()}
+	}
+	return CheckResult{Name: bin, Status: CheckOK, Detail: path}
+}
+
+// searchPath resolves bin against $PATH, analogous to Argo's
+// workflow/util/path.Search
+func searchPath(bin string) (string, error) {
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return "", fmt.Errorf("%s not found on PATH: %w", bin, err)
+	}
+	return path, nil
+}
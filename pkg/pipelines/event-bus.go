@@ -0,0 +1,60 @@
+package pipelines
+
+import "time"
+
+// Event lifecycle statuses published to an EventBus
+const (
+	EventStarted   = "started"
+	EventSucceeded = "succeeded"
+	EventFailed    = "failed"
+)
+
+// Event is a single stage lifecycle update published to an EventBus
+type Event struct {
+	Stage     string
+	Status    string
+	Timestamp time.Time
+	Err       error
+}
+
+// EventBus fans a pipeline's stage lifecycle events out to any number of
+// subscribers, e.g. the bubbletea TUI in pkg/tui or a slog-backed fallback.
+//
+// It satisfies shell.EventSink, so command builders in pkg/shell can publish
+// directly to an EventBus without pkg/shell importing this package
+type EventBus struct {
+	subscribers []chan Event
+}
+
+// NewEventBus returns an empty EventBus
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe returns a channel that receives future Publish calls. The
+// channel is buffered (32 events) so a slow subscriber doesn't block
+// publishers; events published while its buffer is full are dropped
+func (b *EventBus) Subscribe() <-chan Event {
+	ch := make(chan Event, 32)
+	b.subscribers = append(b.subscribers, ch)
+	return ch
+}
+
+// Publish sends an Event built from stage/status/err to every subscriber,
+// dropping it for any subscriber whose buffer is full rather than blocking
+func (b *EventBus) Publish(stage string, status string, err error) {
+	event := Event{Stage: stage, Status: status, Timestamp: time.Now(), Err: err}
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Close closes every subscriber channel. Call once the pipeline is done publishing
+func (b *EventBus) Close() {
+	for _, ch := range b.subscribers {
+		close(ch)
+	}
+}
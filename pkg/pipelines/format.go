@@ -0,0 +1,53 @@
+package pipelines
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Format selects how Versions and Doctor reports are rendered
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+// RenderVersions writes report to w as either aligned human-readable text
+// or JSON, so CI systems can consume it programmatically instead of parsing
+// log lines
+func RenderVersions(w io.Writer, format Format, report map[string]ToolInfo) error {
+	if format == FormatJSON {
+		return json.NewEncoder(w).Encode(report)
+	}
+
+	tools := make([]string, 0, len(report))
+	for tool := range report {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+
+	for _, tool := range tools {
+		info := report[tool]
+		if !info.Available {
+			fmt.Fprintf(w, "%-10s unavailable: %s\n", tool, info.Error)
+			continue
+		}
+		fmt.Fprintf(w, "%-10s %-16s %s\n", tool, info.Version, info.Path)
+	}
+	return nil
+}
+
+// RenderDoctor writes results to w as either aligned human-readable text or JSON
+func RenderDoctor(w io.Writer, format Format, results []CheckResult) error {
+	if format == FormatJSON {
+		return json.NewEncoder(w).Encode(results)
+	}
+
+	for _, result := range results {
+		fmt.Fprintf(w, "[%-4s] %-24s %s\n", result.Status, result.Name, result.Detail)
+	}
+	return nil
+}
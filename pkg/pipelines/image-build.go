@@ -0,0 +1,171 @@
+package pipelines
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"sort"
+	"time"
+	"workflow-engine/pkg/dockerfile"
+	"workflow-engine/pkg/shell"
+)
+
+type ImageBuild struct {
+	Stdout         io.Writer
+	Stderr         io.Writer
+	DryRunEnabled  bool
+	logger         *slog.Logger
+	artifactConfig ArtifactConfig
+	Backend        shell.BuildBackend
+
+	// EventBus, if set, receives stage lifecycle notifications for a
+	// live-progress frontend (e.g. the bubbletea TUI in pkg/tui) to render
+	EventBus *EventBus
+
+	// Dockerfile is the path to the Dockerfile to build
+	Dockerfile string
+	// ContextDir is the build context passed to the backend
+	ContextDir string
+	// ImageRef is the tag applied to the built image. If empty, a
+	// deterministic tag is derived from the Dockerfile's contents
+	ImageRef string
+	// Revision and Source populate the org.opencontainers.image.revision and
+	// .source labels, e.g. a git commit SHA and repository URL
+	Revision string
+	Source   string
+}
+
+func (p *ImageBuild) WithArtifactConfig(config ArtifactConfig) *ImageBuild {
+	p.artifactConfig = config
+	return p
+}
+
+// WithBackend overrides the default (docker) build backend
+func (p *ImageBuild) WithBackend(backend shell.BuildBackend) *ImageBuild {
+	p.Backend = backend
+	return p
+}
+
+// WithEventBus sets the EventBus that this pipeline's stages publish
+// lifecycle notifications to
+func (p *ImageBuild) WithEventBus(bus *EventBus) *ImageBuild {
+	p.EventBus = bus
+	return p
+}
+
+func NewImageBuild(stdout io.Writer, stderr io.Writer) *ImageBuild {
+	return &ImageBuild{
+		Stdout: stdout,
+		Stderr: stderr,
+		artifactConfig: ArtifactConfig{
+			Directory: os.TempDir(),
+		},
+		DryRunEnabled: false,
+		logger:        slog.Default().With("pipeline", "image_build"),
+	}
+}
+
+// Run parses p.Dockerfile, computes the standard OCI labels, builds the
+// image with p.Backend, and saves it as a tarball in the artifact directory
+func (p *ImageBuild) Run() error {
+	p.logger = p.logger.With("dry_run_enabled", p.DryRunEnabled, "dockerfile", p.Dockerfile)
+
+	if p.Backend == nil {
+		backend, err := shell.NewBuildBackend(p.artifactConfig.BuildBackend, p.Stdout, p.Stderr, p.DryRunEnabled)
+		if err != nil {
+			return err
+		}
+		p.Backend = backend
+	}
+
+	// Default backend implementations accept an EventSink for a live-progress
+	// frontend; a caller-supplied implementation opts into this itself
+	if p.EventBus != nil {
+		if publisher, ok := p.Backend.(shell.EventPublishingBuildBackend); ok {
+			publisher.WithEventSink(p.EventBus)
+		}
+	}
+
+	f, err := os.Open(p.Dockerfile)
+	if err != nil {
+		return fmt.Errorf("open dockerfile: %w", err)
+	}
+	defer f.Close()
+
+	parsed, err := dockerfile.Parse(f)
+	if err != nil {
+		return fmt.Errorf("parse dockerfile: %w", err)
+	}
+
+	imageRef := p.ImageRef
+	if imageRef == "" {
+		imageRef = deterministicImageRef(parsed)
+	}
+
+	labels := map[string]string{}
+	for k, v := range parsed.Labels {
+		labels[k] = v
+	}
+	labels["org.opencontainers.image.revision"] = p.Revision
+	labels["org.opencontainers.image.created"] = time.Now().UTC().Format(time.RFC3339)
+	labels["org.opencontainers.image.source"] = p.Source
+
+	p.logger.Debug("build image", "image_ref", imageRef, "context_dir", p.ContextDir)
+	if err := p.Backend.Build(p.Dockerfile, p.ContextDir, imageRef, labels); err != nil {
+		return err
+	}
+
+	tarballPath := p.artifactConfig.ImageTarball
+	if tarballPath == "" {
+		tarballPath = path.Join(p.artifactConfig.Directory, "image.tar")
+		p.artifactConfig.ImageTarball = tarballPath
+	}
+
+	p.logger.Debug("save image", "image_ref", imageRef, "dest", tarballPath)
+	if err := p.Backend.Save(imageRef, tarballPath); err != nil {
+		return err
+	}
+
+	return writeManifest(p.artifactConfig.Directory)
+}
+
+// ImageScan returns an ImageScan pipeline pre-configured to scan the
+// tarball this ImageBuild produced, closing the build -> SBOM -> scan loop
+func (p *ImageBuild) ImageScan() *ImageScan {
+	return NewImageScan(p.Stdout, p.Stderr).WithArtifactConfig(p.artifactConfig)
+}
+
+// deterministicImageRef derives a content-addressed tag from every field
+// the Dockerfile front-end parsed, so repeated builds of unchanged inputs
+// produce the same ref and builds that differ in any instruction (not just
+// FROM/RUN) get a different one. Map fields are hashed in sorted key order
+// so the result doesn't depend on Go's randomized map iteration
+func deterministicImageRef(parsed *dockerfile.Dockerfile) string {
+	h := sha256.New()
+	fmt.Fprintln(h, parsed.From)
+	hashStringMap(h, parsed.Args)
+	hashStringMap(h, parsed.Env)
+	hashStringMap(h, parsed.Labels)
+	for _, cp := range parsed.Copies {
+		fmt.Fprintln(h, cp.Src, cp.Dst)
+	}
+	for _, run := range parsed.Runs {
+		fmt.Fprintln(h, run)
+	}
+	return fmt.Sprintf("workflow-engine-build:%x", h.Sum(nil)[:8])
+}
+
+// hashStringMap writes m's entries to h in sorted key order
+func hashStringMap(h io.Writer, m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintln(h, k, m[k])
+	}
+}
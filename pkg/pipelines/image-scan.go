@@ -6,17 +6,20 @@ import (
 	"log/slog"
 	"os"
 	"path"
-	"workflow-engine/pkg/shell"
 )
 
-const mockSBOMFilename = "../../test/ubuntu_latest_20240125.syft_sbom.json"
-
 type ImageScan struct {
-	Stdout         io.Writer
-	Stderr         io.Writer
-	logger         *slog.Logger
-	DryRunEnabled  bool
-	artifactConfig ArtifactConfig
+	Stdout               io.Writer
+	Stderr               io.Writer
+	logger               *slog.Logger
+	DryRunEnabled        bool
+	artifactConfig       ArtifactConfig
+	SBOMGenerator        SBOMGenerator
+	VulnerabilityScanner VulnerabilityScanner
+
+	// EventBus, if set, receives stage lifecycle notifications for a
+	// live-progress frontend (e.g. the bubbletea TUI in pkg/tui) to render
+	EventBus *EventBus
 }
 
 func (p *ImageScan) WithArtifactConfig(config ArtifactConfig) *ImageScan {
@@ -24,6 +27,25 @@ func (p *ImageScan) WithArtifactConfig(config ArtifactConfig) *ImageScan {
 	return p
 }
 
+// WithEventBus sets the EventBus that this pipeline's stages publish
+// lifecycle notifications to
+func (p *ImageScan) WithEventBus(bus *EventBus) *ImageScan {
+	p.EventBus = bus
+	return p
+}
+
+// WithSBOMGenerator overrides the default (syft) SBOM generator
+func (p *ImageScan) WithSBOMGenerator(generator SBOMGenerator) *ImageScan {
+	p.SBOMGenerator = generator
+	return p
+}
+
+// WithVulnerabilityScanner overrides the default (grype) vulnerability scanner
+func (p *ImageScan) WithVulnerabilityScanner(scanner VulnerabilityScanner) *ImageScan {
+	p.VulnerabilityScanner = scanner
+	return p
+}
+
 func NewImageScan(stdout io.Writer, stderr io.Writer) *ImageScan {
 	return &ImageScan{
 		Stdout: stdout,
@@ -45,34 +67,55 @@ func (p *ImageScan) Run() error {
 		"artifact_config.grype_filename", p.artifactConfig.GrypeFilename,
 	)
 
-	// TODO: need syft SBOM output filename, it'll have to be saved in the artifact directory
-	sbomFilename := path.Join(p.artifactConfig.Directory, p.artifactConfig.SBOMFilename)
-	p.logger.Debug("SIMULATED: create SBOM by copying", "dest", sbomFilename)
-	sbomFile, err := os.Open(mockSBOMFilename)
-	if err != nil {
-		return err
+	// A caller that didn't override the generator/scanner via
+	// WithSBOMGenerator/WithVulnerabilityScanner gets whichever backend was
+	// selected in ArtifactConfig (defaulting to syft/grype)
+	if p.SBOMGenerator == nil {
+		generator, err := NewSBOMGenerator(p.artifactConfig.SBOMGeneratorBackend, p.Stdout, p.Stderr, p.DryRunEnabled)
+		if err != nil {
+			return err
+		}
+		p.SBOMGenerator = generator
+	}
+	if p.VulnerabilityScanner == nil {
+		scanner, err := NewVulnerabilityScanner(p.artifactConfig.VulnerabilityBackend, p.Stderr, p.DryRunEnabled)
+		if err != nil {
+			return err
+		}
+		p.VulnerabilityScanner = scanner
 	}
 
-	f, err := os.Open(sbomFilename)
-	if err != nil {
-		return err
+	// Default generator/scanner implementations capture their command's
+	// stdout/stderr and exit metadata alongside the rest of this pipeline's
+	// artifacts; a caller-supplied implementation opts into capture itself
+	if sbomGenerator, ok := p.SBOMGenerator.(*SyftSBOMGenerator); ok {
+		sbomGenerator.ArtifactDir = p.artifactConfig.Directory
+		if p.EventBus != nil {
+			sbomGenerator.EventBus = p.EventBus
+		}
 	}
-	if _, err := io.Copy(f, sbomFile); err != nil {
-		return err
+	if vulnerabilityScanner, ok := p.VulnerabilityScanner.(*GrypeVulnerabilityScanner); ok {
+		vulnerabilityScanner.ArtifactDir = p.artifactConfig.Directory
+		if p.EventBus != nil {
+			vulnerabilityScanner.EventBus = p.EventBus
+		}
 	}
 
-	// TODO: End where the Syft code will go
+	sbomFilename := path.Join(p.artifactConfig.Directory, p.artifactConfig.SBOMFilename)
+	p.logger.Debug("generate SBOM", "image_tarball", p.artifactConfig.ImageTarball, "dest", sbomFilename)
+	if err := p.SBOMGenerator.Generate(p.artifactConfig.ImageTarball, sbomFilename); err != nil {
+		return err
+	}
 
-	// Holds the grype scan output TODO: multi writer to the artifact directory and gatecheck
+	// Holds the vulnerability scan output TODO: multi writer to the artifact directory and gatecheck
 	buf := new(bytes.Buffer)
 
-	// Do a grype scan on the SBOM, fail if the command fails
-	err = shell.GrypeCommand(buf, p.Stderr).ScanSBOM(p.artifactConfig.SBOMFilename).WithDryRun(p.DryRunEnabled).Run()
-	if err != nil {
+	p.logger.Debug("scan SBOM for vulnerabilities", "src", sbomFilename)
+	if err := p.VulnerabilityScanner.Scan(sbomFilename, buf); err != nil {
 		return err
 	}
 
-	// Save the grype file to the artifact directory
+	// Save the vulnerability scan report to the artifact directory
 	grypeFilename := path.Join(p.artifactConfig.Directory, p.artifactConfig.GrypeFilename)
 	p.logger.Debug("open grype artifact", "dest", grypeFilename)
 	grypeFile, err := os.OpenFile(grypeFilename, os.O_CREATE|os.O_WRONLY, 0644)
@@ -85,5 +128,9 @@ func (p *ImageScan) Run() error {
 		return err
 	}
 
+	if err := writeManifest(p.artifactConfig.Directory); err != nil {
+		return err
+	}
+
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,73 @@
+package pipelines
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"workflow-engine/pkg/shell/shelltest"
+)
+
+func TestImageScanRunUsesFakeRunner(t *testing.T) {
+	dir := t.TempDir()
+	fake := shelltest.NewFakeRunner()
+
+	var stdout, stderr bytes.Buffer
+	scan := NewImageScan(&stdout, &stderr).
+		WithArtifactConfig(ArtifactConfig{
+			Directory:     dir,
+			ImageTarball:  filepath.Join(dir, "image.tar"),
+			SBOMFilename:  "sbom.json",
+			GrypeFilename: "grype.json",
+		}).
+		WithSBOMGenerator(&SyftSBOMGenerator{Stdout: &stdout, Stderr: &stderr, Runner: fake}).
+		WithVulnerabilityScanner(&GrypeVulnerabilityScanner{Stderr: &stderr, Runner: fake})
+
+	if err := scan.Run(); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	if len(fake.Calls) != 2 {
+		t.Fatalf("expected 2 commands run via the fake runner, got %d", len(fake.Calls))
+	}
+	if got := filepath.Base(fake.Calls[0].Path); got != "syft" {
+		t.Errorf("expected first command to be syft, got %q", got)
+	}
+	if got := filepath.Base(fake.Calls[1].Path); got != "grype" {
+		t.Errorf("expected second command to be grype, got %q", got)
+	}
+
+	grypeArgs := strings.Join(fake.Calls[1].Args, " ")
+	if !strings.Contains(grypeArgs, "sbom:"+filepath.Join(dir, "sbom.json")) {
+		t.Errorf("expected grype invocation to scan the generated sbom, got args %q", grypeArgs)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "grype.json")); err != nil {
+		t.Errorf("expected grype report to be written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "manifest.json")); err != nil {
+		t.Errorf("expected manifest to be written: %v", err)
+	}
+}
+
+func TestImageScanRunSurfacesGeneratorError(t *testing.T) {
+	dir := t.TempDir()
+	fake := shelltest.NewFakeRunner()
+	fake.Errors["syft"] = os.ErrPermission
+
+	var stdout, stderr bytes.Buffer
+	scan := NewImageScan(&stdout, &stderr).
+		WithArtifactConfig(ArtifactConfig{
+			Directory:     dir,
+			ImageTarball:  filepath.Join(dir, "image.tar"),
+			SBOMFilename:  "sbom.json",
+			GrypeFilename: "grype.json",
+		}).
+		WithSBOMGenerator(&SyftSBOMGenerator{Stdout: &stdout, Stderr: &stderr, Runner: fake})
+
+	err := scan.Run()
+	if err == nil {
+		t.Fatal("expected Run() to return an error when the fake runner fails syft")
+	}
+}
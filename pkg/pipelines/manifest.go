@@ -0,0 +1,39 @@
+package pipelines
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Manifest lists the artifact files a pipeline produced in its artifact
+// directory, including any command capture files written by
+// shell.WithArtifactCapture, for later gatecheck-style aggregation
+type Manifest struct {
+	ArtifactDir string   `json:"artifact_dir"`
+	Files       []string `json:"files"`
+}
+
+// writeManifest walks dir non-recursively and writes "manifest.json" listing
+// every file it finds
+func writeManifest(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	manifest := Manifest{ArtifactDir: dir}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			manifest.Files = append(manifest.Files, entry.Name())
+		}
+	}
+
+	f, err := os.Create(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(manifest)
+}
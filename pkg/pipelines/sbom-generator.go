@@ -0,0 +1,70 @@
+package pipelines
+
+import (
+	"fmt"
+	"io"
+	"time"
+	"workflow-engine/pkg/shell"
+)
+
+// SBOMGenerator produces a Software Bill of Materials for a container image tarball
+type SBOMGenerator interface {
+	Generate(imageTarball string, sbomFilename string) error
+}
+
+// SyftSBOMGenerator generates an SBOM by shelling out to syft
+type SyftSBOMGenerator struct {
+	Stdout        io.Writer
+	Stderr        io.Writer
+	DryRunEnabled bool
+
+	// ArtifactDir, if set, captures the syft invocation's stdout/stderr and
+	// exit metadata via shell.WithArtifactCapture
+	ArtifactDir string
+
+	// Timeout bounds how long the syft invocation is allowed to run before
+	// it's killed. Zero means no timeout
+	Timeout time.Duration
+
+	// Runner overrides the shell.CommandRunner used to invoke syft, primarily
+	// for injecting a shelltest.FakeRunner in tests
+	Runner shell.CommandRunner
+
+	// EventBus, if set, receives start/stop notifications for the scan under
+	// the "generate-sbom" stage name, for a live-progress frontend to render
+	EventBus shell.EventSink
+}
+
+func (g *SyftSBOMGenerator) Generate(imageTarball string, sbomFilename string) error {
+	cmd := shell.SyftCommand(g.Stdout, g.Stderr).
+		ScanImage(imageTarball, sbomFilename).
+		WithDryRun(g.DryRunEnabled)
+
+	if g.ArtifactDir != "" {
+		cmd = cmd.WithArtifactCapture(g.ArtifactDir)
+	}
+	if g.Timeout > 0 {
+		cmd = cmd.WithTimeout(g.Timeout)
+	}
+	if g.Runner != nil {
+		cmd = cmd.WithRunner(g.Runner)
+	}
+	if g.EventBus != nil {
+		cmd = cmd.WithEventSink("generate-sbom", g.EventBus)
+	}
+
+	return cmd.Run()
+}
+
+// NewSBOMGenerator selects an SBOMGenerator implementation by backend name.
+//
+// Today only "syft" is supported; additional backends (e.g. trivy) can be
+// added here without changing any pipeline call sites
+func NewSBOMGenerator(backend string, stdout io.Writer, stderr io.Writer, dryRunEnabled bool) (SBOMGenerator, error) {
+	switch backend {
+	case "", "syft":
+		return &SyftSBOMGenerator{Stdout: stdout, Stderr: stderr, DryRunEnabled: dryRunEnabled}, nil
+	default:
+		return nil, fmt.Errorf("unsupported SBOM generator backend: %q", backend)
+	}
+}
@@ -0,0 +1,92 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"workflow-engine/pkg/shell"
+)
+
+// ToolInfo reports the state of a single CLI dependency
+type ToolInfo struct {
+	Version   string `json:"version,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Available bool   `json:"available"`
+	Error     string `json:"error,omitempty"`
+}
+
+// versionTools is the set of CLI dependencies probed by Versions
+var versionTools = []string{"syft", "grype", "docker", "podman"}
+
+// Versions probes `<tool> version` for each tool in versionTools (syft,
+// grype, docker, and podman; buildah is not probed), replacing the
+// version-checking half of the old Debug pipeline
+type Versions struct {
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Runner overrides the shell.CommandRunner used to invoke each tool,
+	// primarily for injecting a shelltest.FakeRunner in tests
+	Runner shell.CommandRunner
+
+	logger *slog.Logger
+}
+
+// NewVersions creates a new Versions pipeline with custom stdout and stderr
+func NewVersions(stdout io.Writer, stderr io.Writer) *Versions {
+	return &Versions{
+		Stdout: stdout,
+		Stderr: stderr,
+		Runner: shell.NewCommandRunner(),
+		logger: slog.Default().With("pipeline", "versions"),
+	}
+}
+
+// Run probes every tool in versionTools, returning a ToolInfo per tool.
+//
+// A tool missing from $PATH or exiting non-zero is reported via
+// ToolInfo.Error rather than failing the whole probe, since an unavailable
+// optional tool (e.g. podman) isn't itself an error condition
+func (v *Versions) Run() map[string]ToolInfo {
+	report := make(map[string]ToolInfo, len(versionTools))
+	for _, tool := range versionTools {
+		report[tool] = v.probe(tool)
+	}
+	return report
+}
+
+func (v *Versions) probe(tool string) ToolInfo {
+	path, err := exec.LookPath(tool)
+	if err != nil {
+		v.logger.Debug("tool not found", "tool", tool, "error", err)
+		return ToolInfo{Error: err.Error()}
+	}
+
+	cmd := exec.Command(tool, "version")
+	cmd.Stderr = v.Stderr
+
+	result, err := v.Runner.RunCmd(context.Background(), cmd)
+	if err != nil {
+		return ToolInfo{Path: path, Error: err.Error()}
+	}
+	if result.ExitCode != shell.ExitOK {
+		return ToolInfo{Path: path, Error: fmt.Sprintf("exit code %d", result.ExitCode)}
+	}
+
+	return ToolInfo{
+		Path:      path,
+		Available: true,
+		Version:   strings.TrimSpace(firstLine(string(result.Stdout))),
+	}
+}
+
+// firstLine returns s up to (not including) its first newline
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
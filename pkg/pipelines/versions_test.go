@@ -0,0 +1,57 @@
+package pipelines
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"workflow-engine/pkg/shell"
+	"workflow-engine/pkg/shell/shelltest"
+)
+
+// stubExecutable creates an empty, executable file named name under dir so
+// exec.LookPath resolves it without anything ever actually running it (the
+// FakeRunner intercepts RunCmd before the real binary would be invoked)
+func stubExecutable(t *testing.T, dir string, name string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, nil, 0o755); err != nil {
+		t.Fatalf("stub %s: %v", name, err)
+	}
+}
+
+func TestVersionsRun(t *testing.T) {
+	dir := t.TempDir()
+	stubExecutable(t, dir, "syft")
+	stubExecutable(t, dir, "docker")
+	t.Setenv("PATH", dir)
+
+	fake := shelltest.NewFakeRunner()
+	fake.Results["syft"] = shell.CommandResult{ExitCode: shell.ExitOK, Stdout: []byte("syft 1.2.3\nadditional output\n")}
+	fake.Results["docker"] = shell.CommandResult{ExitCode: 1}
+
+	var stdout, stderr bytes.Buffer
+	versions := NewVersions(&stdout, &stderr)
+	versions.Runner = fake
+
+	report := versions.Run()
+
+	syftInfo := report["syft"]
+	if !syftInfo.Available || syftInfo.Version != "syft 1.2.3" || syftInfo.Error != "" {
+		t.Errorf("expected syft to report available with trimmed version, got %+v", syftInfo)
+	}
+
+	dockerInfo := report["docker"]
+	if dockerInfo.Available || dockerInfo.Error == "" {
+		t.Errorf("expected docker to report unavailable with an exit-code error, got %+v", dockerInfo)
+	}
+
+	grypeInfo := report["grype"]
+	if grypeInfo.Available || grypeInfo.Error == "" {
+		t.Errorf("expected grype, which isn't on PATH, to report unavailable, got %+v", grypeInfo)
+	}
+
+	if len(fake.Calls) != 2 {
+		t.Fatalf("expected the fake runner to be invoked for syft and docker only, got %d calls", len(fake.Calls))
+	}
+}
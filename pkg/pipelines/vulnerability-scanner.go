@@ -0,0 +1,70 @@
+package pipelines
+
+import (
+	"fmt"
+	"io"
+	"time"
+	"workflow-engine/pkg/shell"
+)
+
+// VulnerabilityScanner scans a generated SBOM for known vulnerabilities,
+// writing the scan report to w
+type VulnerabilityScanner interface {
+	Scan(sbomFilename string, w io.Writer) error
+}
+
+// GrypeVulnerabilityScanner scans an SBOM by shelling out to grype
+type GrypeVulnerabilityScanner struct {
+	Stderr        io.Writer
+	DryRunEnabled bool
+
+	// ArtifactDir, if set, captures the grype invocation's stdout/stderr and
+	// exit metadata via shell.WithArtifactCapture
+	ArtifactDir string
+
+	// Timeout bounds how long the grype invocation is allowed to run before
+	// it's killed. Zero means no timeout
+	Timeout time.Duration
+
+	// Runner overrides the shell.CommandRunner used to invoke grype, primarily
+	// for injecting a shelltest.FakeRunner in tests
+	Runner shell.CommandRunner
+
+	// EventBus, if set, receives start/stop notifications for the scan under
+	// the "scan-vulnerabilities" stage name, for a live-progress frontend to render
+	EventBus shell.EventSink
+}
+
+func (s *GrypeVulnerabilityScanner) Scan(sbomFilename string, w io.Writer) error {
+	cmd := shell.GrypeCommand(w, s.Stderr).
+		ScanSBOM(sbomFilename).
+		WithDryRun(s.DryRunEnabled)
+
+	if s.ArtifactDir != "" {
+		cmd = cmd.WithArtifactCapture(s.ArtifactDir)
+	}
+	if s.Timeout > 0 {
+		cmd = cmd.WithTimeout(s.Timeout)
+	}
+	if s.Runner != nil {
+		cmd = cmd.WithRunner(s.Runner)
+	}
+	if s.EventBus != nil {
+		cmd = cmd.WithEventSink("scan-vulnerabilities", s.EventBus)
+	}
+
+	return cmd.Run()
+}
+
+// NewVulnerabilityScanner selects a VulnerabilityScanner implementation by backend name.
+//
+// Today only "grype" is supported; additional backends (e.g. trivy) can be
+// added here without changing any pipeline call sites
+func NewVulnerabilityScanner(backend string, stderr io.Writer, dryRunEnabled bool) (VulnerabilityScanner, error) {
+	switch backend {
+	case "", "grype":
+		return &GrypeVulnerabilityScanner{Stderr: stderr, DryRunEnabled: dryRunEnabled}, nil
+	default:
+		return nil, fmt.Errorf("unsupported vulnerability scanner backend: %q", backend)
+	}
+}
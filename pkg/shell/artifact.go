@@ -0,0 +1,91 @@
+package shell
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// captureSeq is a process-wide counter used to disambiguate the artifact
+// prefix for commands that share a base name (e.g. a "syft version" probe
+// followed by a "syft scan" in the same artifact directory), so the second
+// invocation doesn't clobber the first's capture files
+var captureSeq atomic.Uint64
+
+// CommandArtifact is the structured exit metadata written as a "<cmd>.json"
+// sidecar for every command run with WithArtifactCapture
+type CommandArtifact struct {
+	Argv      []string  `json:"argv"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	ExitCode  int       `json:"exit_code"`
+	Killed    bool      `json:"killed"`
+}
+
+// commandArtifactCapture tees a single command invocation's stdout/stderr to
+// disk and accumulates the metadata needed for its CommandArtifact sidecar
+type commandArtifactCapture struct {
+	dir       string
+	prefix    string
+	argv      []string
+	startedAt time.Time
+	stdout    *os.File
+	stderr    *os.File
+}
+
+// newCommandArtifactCapture creates the "<cmd>.stdout" and "<cmd>.stderr"
+// files for cmd under dir, creating dir if it doesn't already exist
+func newCommandArtifactCapture(dir string, cmd *exec.Cmd) (*commandArtifactCapture, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("%s-%d", filepath.Base(cmd.Path), captureSeq.Add(1))
+
+	stdout, err := os.Create(filepath.Join(dir, prefix+".stdout"))
+	if err != nil {
+		return nil, err
+	}
+
+	stderr, err := os.Create(filepath.Join(dir, prefix+".stderr"))
+	if err != nil {
+		stdout.Close()
+		return nil, err
+	}
+
+	return &commandArtifactCapture{
+		dir:       dir,
+		prefix:    prefix,
+		argv:      cmd.Args,
+		startedAt: time.Now(),
+		stdout:    stdout,
+		stderr:    stderr,
+	}, nil
+}
+
+// finish closes the stdout/stderr capture files and writes the "<cmd>.json"
+// sidecar describing how the command exited
+func (c *commandArtifactCapture) finish(exitCode int, killed bool) error {
+	c.stdout.Close()
+	c.stderr.Close()
+
+	artifact := CommandArtifact{
+		Argv:      c.argv,
+		StartedAt: c.startedAt,
+		EndedAt:   time.Now(),
+		ExitCode:  exitCode,
+		Killed:    killed,
+	}
+
+	f, err := os.Create(filepath.Join(c.dir, c.prefix+".json"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(artifact)
+}
@@ -0,0 +1,136 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+)
+
+// buildArgs assembles the `build -f <dockerfile> -t <imageRef> --label k=v... <contextDir>`
+// argv shared by docker build, podman build, and buildah bud. Labels are
+// sorted by key so the resulting argv is deterministic
+func buildArgs(dockerfile string, contextDir string, imageRef string, labels map[string]string) []string {
+	args := []string{"build", "-f", dockerfile, "-t", imageRef}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--label", k+"="+labels[k])
+	}
+
+	return append(args, contextDir)
+}
+
+// BuildahCommand creates a new command builder for buildah
+func BuildahCommand(stdout io.Writer, stderr io.Writer) *cmdBuilder {
+	return newCmdBuilder("buildah", stdout, stderr)
+}
+
+// Bud runs `buildah bud`, tagging the result as imageRef and applying labels
+func (c *cmdBuilder) Bud(dockerfile string, contextDir string, imageRef string, labels map[string]string) *cmdBuilder {
+	args := append([]string{"bud"}, buildArgs(dockerfile, contextDir, imageRef, labels)[1:]...)
+	c.cmd = exec.Command("buildah", args...)
+	return c
+}
+
+// Push runs `buildah push`, writing imageRef as an OCI archive to tarballPath
+func (c *cmdBuilder) Push(imageRef string, tarballPath string) *cmdBuilder {
+	c.cmd = exec.Command("buildah", "push", imageRef, "oci-archive:"+tarballPath)
+	return c
+}
+
+// BuildBackend builds a container image from a Dockerfile and saves it as a
+// tarball, dispatching to docker, podman, or buildah
+type BuildBackend interface {
+	Build(dockerfile string, contextDir string, imageRef string, labels map[string]string) error
+	Save(imageRef string, tarballPath string) error
+}
+
+// EventPublishingBuildBackend is implemented by BuildBackend implementations
+// that can publish stage lifecycle notifications to an EventSink. The
+// built-in docker/podman/buildah backends all implement it; callers type-assert
+// for it since BuildBackend itself stays minimal
+type EventPublishingBuildBackend interface {
+	BuildBackend
+	WithEventSink(sink EventSink)
+}
+
+// execBuildBackend builds images by shelling out to docker, podman, or
+// buildah. The three tools' build/save verbs differ only in argv shape
+// (buildah uses "bud"/"push oci-archive:..." instead of "build"/"save"),
+// which dispatchBuild/dispatchSave account for
+type execBuildBackend struct {
+	tool           string
+	Stdout, Stderr io.Writer
+	DryRunEnabled  bool
+
+	// EventSink, if set, receives start/stop notifications for the build and
+	// save stages under the "build-image"/"save-image" stage names
+	EventSink EventSink
+}
+
+func (b *execBuildBackend) Build(dockerfile string, contextDir string, imageRef string, labels map[string]string) error {
+	cmd := b.dispatchBuild(dockerfile, contextDir, imageRef, labels).WithDryRun(b.DryRunEnabled)
+	if b.EventSink != nil {
+		cmd = cmd.WithEventSink("build-image", b.EventSink)
+	}
+	return cmd.Run()
+}
+
+func (b *execBuildBackend) Save(imageRef string, tarballPath string) error {
+	cmd := b.dispatchSave(imageRef, tarballPath).WithDryRun(b.DryRunEnabled)
+	if b.EventSink != nil {
+		cmd = cmd.WithEventSink("save-image", b.EventSink)
+	}
+	return cmd.Run()
+}
+
+// WithEventSink sets the sink that the build and save stages publish
+// lifecycle notifications to
+func (b *execBuildBackend) WithEventSink(sink EventSink) {
+	b.EventSink = sink
+}
+
+func (b *execBuildBackend) commandBuilder() *cmdBuilder {
+	switch b.tool {
+	case "podman":
+		return PodmanCommand(b.Stdout, b.Stderr)
+	case "buildah":
+		return BuildahCommand(b.Stdout, b.Stderr)
+	default:
+		return DockerCommand(b.Stdout, b.Stderr)
+	}
+}
+
+func (b *execBuildBackend) dispatchBuild(dockerfile string, contextDir string, imageRef string, labels map[string]string) *cmdBuilder {
+	if b.tool == "buildah" {
+		return b.commandBuilder().Bud(dockerfile, contextDir, imageRef, labels)
+	}
+	return b.commandBuilder().Build(dockerfile, contextDir, imageRef, labels)
+}
+
+func (b *execBuildBackend) dispatchSave(imageRef string, tarballPath string) *cmdBuilder {
+	if b.tool == "buildah" {
+		return b.commandBuilder().Push(imageRef, tarballPath)
+	}
+	return b.commandBuilder().Save(imageRef, tarballPath)
+}
+
+// NewBuildBackend selects a BuildBackend implementation by name: "docker"
+// (the default), "podman", or "buildah"
+func NewBuildBackend(backend string, stdout io.Writer, stderr io.Writer, dryRunEnabled bool) (BuildBackend, error) {
+	switch backend {
+	case "", "docker", "podman", "buildah":
+		tool := backend
+		if tool == "" {
+			tool = "docker"
+		}
+		return &execBuildBackend{tool: tool, Stdout: stdout, Stderr: stderr, DryRunEnabled: dryRunEnabled}, nil
+	default:
+		return nil, fmt.Errorf("unsupported build backend: %q", backend)
+	}
+}
@@ -0,0 +1,138 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"time"
+)
+
+// cmdBuilder is the shared command builder for every tool CLI this package
+// wraps (syft, grype, docker, podman, buildah). Only the verb methods that
+// assemble the underlying exec.Cmd (ScanImage, ScanSBOM, Build, Save, ...)
+// are tool-specific; WithDryRun, WithArtifactCapture, WithRunner,
+// WithEventSink, Run, and RunLogErrorAsWarning behave identically for every
+// tool and live here once instead of being duplicated per builder
+type cmdBuilder struct {
+	tool           string
+	stdout, stderr io.Writer
+	cmd            *exec.Cmd
+	options        []OptionFunc
+	runner         CommandRunner
+}
+
+// newCmdBuilder returns a cmdBuilder for the named tool CLI, using the
+// default (os/exec-backed) CommandRunner until overridden via WithRunner
+func newCmdBuilder(tool string, stdout io.Writer, stderr io.Writer) *cmdBuilder {
+	return &cmdBuilder{tool: tool, stdout: stdout, stderr: stderr, runner: NewCommandRunner()}
+}
+
+// Version runs `<tool> version`
+func (c *cmdBuilder) Version() *cmdBuilder {
+	c.cmd = exec.Command(c.tool, "version")
+	return c
+}
+
+// WithDryRun sets the dry run option on the underlying command
+func (c *cmdBuilder) WithDryRun(enabled bool) *cmdBuilder {
+	c.options = append(c.options, WithDryRun(enabled))
+	return c
+}
+
+// WithArtifactCapture tees the command's stdout/stderr to disk and writes
+// an exit metadata sidecar under dir (see WithArtifactCapture in shell.go)
+func (c *cmdBuilder) WithArtifactCapture(dir string) *cmdBuilder {
+	c.options = append(c.options, WithArtifactCapture(dir))
+	return c
+}
+
+// WithTimeout bounds how long the command is allowed to run before it's
+// killed (see WithTimeout in shell.go)
+func (c *cmdBuilder) WithTimeout(d time.Duration) *cmdBuilder {
+	c.options = append(c.options, WithTimeout(d))
+	return c
+}
+
+// WithCombinedOutput additionally tees the command's stdout and stderr to w
+// (see WithCombinedOutput in shell.go)
+func (c *cmdBuilder) WithCombinedOutput(w io.Writer) *cmdBuilder {
+	c.options = append(c.options, WithCombinedOutput(w))
+	return c
+}
+
+// WithRunner overrides the CommandRunner used to execute the command,
+// primarily for injecting a fake in tests
+func (c *cmdBuilder) WithRunner(runner CommandRunner) *cmdBuilder {
+	c.runner = runner
+	return c
+}
+
+// WithEventSink publishes start/stop lifecycle notifications for this
+// command to sink under the given stage name
+func (c *cmdBuilder) WithEventSink(stage string, sink EventSink) *cmdBuilder {
+	c.options = append(c.options, WithEventSink(stage, sink))
+	return c
+}
+
+// Run executes the configured command, returning an error if it exits non-zero
+func (c *cmdBuilder) Run() error {
+	o := newOptions(append(c.options, WithIO(nil, c.stdout, c.stderr))...)
+	if exitCode := runViaRunner(c.runner, c.cmd, o); exitCode != ExitOK {
+		return fmt.Errorf("%s command failed with exit code %d", c.tool, exitCode)
+	}
+	return nil
+}
+
+// RunLogErrorAsWarning runs the command and logs a failure as a warning instead of returning an error
+func (c *cmdBuilder) RunLogErrorAsWarning() {
+	if err := c.Run(); err != nil {
+		slog.Warn("non-fatal command failure", "command", c.tool, "error", err)
+	}
+}
+
+// SyftCommand creates a new command builder for syft, a CLI tool for generating SBOMs
+func SyftCommand(stdout io.Writer, stderr io.Writer) *cmdBuilder {
+	return newCmdBuilder("syft", stdout, stderr)
+}
+
+// ScanImage runs `syft scan` against a docker-archive tarball, writing the SBOM to sbomFilename
+func (c *cmdBuilder) ScanImage(imageTarball string, sbomFilename string) *cmdBuilder {
+	c.cmd = exec.Command("syft", "scan", "docker-archive:"+imageTarball, "-o", "json="+sbomFilename)
+	return c
+}
+
+// GrypeCommand creates a new command builder for grype, a CLI vulnerability scanner
+func GrypeCommand(stdout io.Writer, stderr io.Writer) *cmdBuilder {
+	return newCmdBuilder("grype", stdout, stderr)
+}
+
+// ScanSBOM runs `grype` against a previously generated SBOM file
+func (c *cmdBuilder) ScanSBOM(sbomFilename string) *cmdBuilder {
+	c.cmd = exec.Command("grype", "sbom:"+sbomFilename, "-o", "json")
+	return c
+}
+
+// DockerCommand creates a new command builder for docker
+func DockerCommand(stdout io.Writer, stderr io.Writer) *cmdBuilder {
+	return newCmdBuilder("docker", stdout, stderr)
+}
+
+// PodmanCommand creates a new command builder for podman
+func PodmanCommand(stdout io.Writer, stderr io.Writer) *cmdBuilder {
+	return newCmdBuilder("podman", stdout, stderr)
+}
+
+// Build runs `<tool> build`, tagging the result as imageRef and applying labels.
+// Shared by docker and podman, whose build argv is identical
+func (c *cmdBuilder) Build(dockerfile string, contextDir string, imageRef string, labels map[string]string) *cmdBuilder {
+	c.cmd = exec.Command(c.tool, buildArgs(dockerfile, contextDir, imageRef, labels)...)
+	return c
+}
+
+// Save runs `<tool> save`, writing imageRef as a tarball to tarballPath.
+// Shared by docker and podman, whose save argv is identical
+func (c *cmdBuilder) Save(imageRef string, tarballPath string) *cmdBuilder {
+	c.cmd = exec.Command(c.tool, "save", "-o", tarballPath, imageRef)
+	return c
+}
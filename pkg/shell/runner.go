@@ -0,0 +1,61 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"time"
+)
+
+// CommandResult is the outcome of running a command via a CommandRunner
+type CommandResult struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+	Duration time.Duration
+}
+
+// CommandRunner executes an *exec.Cmd and reports its result.
+//
+// Command builders depend on this interface rather than calling exec
+// directly, so pipelines built on top of them can be tested against a fake
+// implementation (see the shelltest package) instead of shelling out.
+type CommandRunner interface {
+	RunCmd(ctx context.Context, cmd *exec.Cmd) (CommandResult, error)
+}
+
+// execRunner is the default CommandRunner, backed by os/exec
+type execRunner struct{}
+
+// NewCommandRunner returns the default CommandRunner, which shells out via os/exec
+func NewCommandRunner() CommandRunner {
+	return &execRunner{}
+}
+
+// RunCmd starts cmd, waits for it to complete or ctx to be done, and
+// captures its stdout/stderr alongside whatever writers cmd already has set
+func (r *execRunner) RunCmd(ctx context.Context, cmd *exec.Cmd) (CommandResult, error) {
+	var stdout, stderr bytes.Buffer
+
+	if cmd.Stdout != nil {
+		cmd.Stdout = io.MultiWriter(cmd.Stdout, &stdout)
+	} else {
+		cmd.Stdout = &stdout
+	}
+	if cmd.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, &stderr)
+	} else {
+		cmd.Stderr = &stderr
+	}
+
+	start := time.Now()
+	exitCode, _ := runAndWait(cmd, &Options{ctx: ctx})
+
+	return CommandResult{
+		Stdout:   stdout.Bytes(),
+		Stderr:   stderr.Bytes(),
+		ExitCode: exitCode,
+		Duration: time.Since(start),
+	}, nil
+}
@@ -3,9 +3,11 @@ package shell
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"os/exec"
+	"time"
 )
 
 const ExitOK int = 0
@@ -23,11 +25,16 @@ type Command func(...OptionFunc) int
 
 // Options are flexible parameters for any command
 type Options struct {
-	dryRunEnabled bool
-	stdin         io.Reader
-	stdout        io.Writer
-	stderr        io.Writer
-	ctx           context.Context
+	dryRunEnabled      bool
+	stdin              io.Reader
+	stdout             io.Writer
+	stderr             io.Writer
+	ctx                context.Context
+	artifactCaptureDir string
+	timeout            time.Duration
+	combinedOutput     io.Writer
+	eventSink          EventSink
+	stageName          string
 }
 
 // apply should be called before the exec.Cmd is run
@@ -84,26 +91,136 @@ func WithStderr(w io.Writer) OptionFunc {
 	}
 }
 
-// run handles the execution of the command
+// WithArtifactCapture tees a command's stdout/stderr to "<cmd>.stdout" and
+// "<cmd>.stderr" files under dir, and writes a "<cmd>.json" sidecar
+// containing the resolved argv, start/end timestamps, exit code, and
+// whether the command was killed by context cancellation.
 //
-// context will be set to background if not provided in the o.ctx
-// this enables the command to be terminated before completion
-// if ctx fires done.
+// Modeled in spirit after Argo's emissary executor, which tees process
+// output to disk so it survives past the lifetime of the pipeline that
+// started it.
+func WithArtifactCapture(dir string) OptionFunc {
+	return func(o *Options) {
+		o.artifactCaptureDir = dir
+	}
+}
+
+// WithTimeout bounds how long the command is allowed to run before it's
+// killed, in addition to any deadline already carried by the context
+func WithTimeout(d time.Duration) OptionFunc {
+	return func(o *Options) {
+		o.timeout = d
+	}
+}
+
+// WithCombinedOutput additionally tees the command's stdout and stderr to w
+func WithCombinedOutput(w io.Writer) OptionFunc {
+	return func(o *Options) {
+		o.combinedOutput = w
+	}
+}
+
+// EventSink receives a command's start/stop lifecycle notifications.
+//
+// pipelines.EventBus satisfies this interface structurally, so pkg/shell can
+// publish to it without importing pkg/pipelines
+type EventSink interface {
+	Publish(stage string, status string, err error)
+}
+
+// WithEventSink publishes "started"/"succeeded"/"failed" notifications for
+// this command to sink under the given stage name, for a live-progress
+// frontend (e.g. the bubbletea TUI in pkg/tui) to render
+func WithEventSink(stage string, sink EventSink) OptionFunc {
+	return func(o *Options) {
+		o.stageName = stage
+		o.eventSink = sink
+	}
+}
+
+// runViaRunner resolves the stdin/stdout/stderr, timeout, artifact capture,
+// and dry run options, then hands the configured cmd off to runner.
 //
-// Setting the dry run option will always return ExitOK
-func run(cmd *exec.Cmd, o *Options) int {
+// Setting the dry run option will always return ExitOK without invoking runner
+func runViaRunner(runner CommandRunner, cmd *exec.Cmd, o *Options) int {
 	slog.Info("shell exec", "dry_run", o.dryRunEnabled, "command", cmd.String())
 	if o.dryRunEnabled {
 		return ExitOK
 	}
 
+	if o.eventSink != nil {
+		o.eventSink.Publish(o.stageName, "started", nil)
+	}
+
 	cmd.Stdin = o.stdin
 	cmd.Stdout = o.stdout
 	cmd.Stderr = o.stderr
+	if o.combinedOutput != nil {
+		cmd.Stdout = io.MultiWriter(cmd.Stdout, o.combinedOutput)
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, o.combinedOutput)
+	}
 
-	if err := cmd.Start(); err != nil {
+	var capture *commandArtifactCapture
+	if o.artifactCaptureDir != "" {
+		var err error
+		capture, err = newCommandArtifactCapture(o.artifactCaptureDir, cmd)
+		if err != nil {
+			slog.Error("artifact capture setup failed", "error", err)
+		} else {
+			cmd.Stdout = io.MultiWriter(cmd.Stdout, capture.stdout)
+			cmd.Stderr = io.MultiWriter(cmd.Stderr, capture.stderr)
+		}
+	}
+
+	ctx := o.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	result, err := runner.RunCmd(ctx, cmd)
+	if err != nil {
+		slog.Error("command runner failed", "error", err)
+		if capture != nil {
+			if err := capture.finish(ExitUnknown, false); err != nil {
+				slog.Error("artifact capture finish failed", "error", err)
+			}
+		}
+		if o.eventSink != nil {
+			o.eventSink.Publish(o.stageName, "failed", err)
+		}
 		return ExitUnknown
 	}
+
+	if capture != nil {
+		killed := result.ExitCode == ExitContextCancel
+		if err := capture.finish(result.ExitCode, killed); err != nil {
+			slog.Error("artifact capture finish failed", "error", err)
+		}
+	}
+
+	if o.eventSink != nil {
+		if result.ExitCode == ExitOK {
+			o.eventSink.Publish(o.stageName, "succeeded", nil)
+		} else {
+			o.eventSink.Publish(o.stageName, "failed", fmt.Errorf("exit code %d", result.ExitCode))
+		}
+	}
+
+	return result.ExitCode
+}
+
+// runAndWait starts cmd and waits for either normal completion or context
+// cancellation, returning the resolved exit code and whether the process
+// was killed as a result of the context being done
+func runAndWait(cmd *exec.Cmd, o *Options) (exitCode int, killed bool) {
+	if err := cmd.Start(); err != nil {
+		return ExitUnknown, false
+	}
 	if o.ctx == nil {
 		o.ctx = context.Background()
 	}
@@ -117,18 +234,18 @@ func run(cmd *exec.Cmd, o *Options) int {
 	select {
 	case <-o.ctx.Done():
 		if err := cmd.Process.Kill(); err != nil {
-			return ExitKillFailure
+			return ExitKillFailure, true
 		}
-		return ExitContextCancel
+		return ExitContextCancel, true
 	case <-doneChan:
 		var exitCodeError *exec.ExitError
 		if errors.As(runError, &exitCodeError) {
-			return exitCodeError.ExitCode()
+			return exitCodeError.ExitCode(), false
 		}
 		if runError != nil {
-			return ExitUnknown
+			return ExitUnknown, false
 		}
 	}
 
-	return ExitOK
+	return ExitOK, false
 }
\ No newline at end of file
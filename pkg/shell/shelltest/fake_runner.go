@@ -0,0 +1,49 @@
+// Package shelltest provides test doubles for the shell.CommandRunner
+// interface so pipelines can be unit-tested without shelling out
+package shelltest
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"time"
+	"workflow-engine/pkg/shell"
+)
+
+// FakeRunner is a shell.CommandRunner that never executes a real process.
+//
+// Results is keyed by the command's base name (e.g. "syft", "grype") so a
+// test can stage a distinct CommandResult/error per tool. Calls is appended
+// to in invocation order so a test can assert on the argv that would have run.
+type FakeRunner struct {
+	Results map[string]shell.CommandResult
+	Errors  map[string]error
+	Calls   []*exec.Cmd
+}
+
+// NewFakeRunner returns a FakeRunner with empty result/error tables
+func NewFakeRunner() *FakeRunner {
+	return &FakeRunner{
+		Results: make(map[string]shell.CommandResult),
+		Errors:  make(map[string]error),
+	}
+}
+
+// RunCmd records cmd and returns the staged result/error for cmd's base
+// name, defaulting to a CommandResult with ExitCode 0, no output, and a 1ms
+// Duration
+func (f *FakeRunner) RunCmd(_ context.Context, cmd *exec.Cmd) (shell.CommandResult, error) {
+	f.Calls = append(f.Calls, cmd)
+
+	name := filepath.Base(cmd.Path)
+	if err, ok := f.Errors[name]; ok {
+		return shell.CommandResult{}, err
+	}
+
+	result, ok := f.Results[name]
+	if !ok {
+		result = shell.CommandResult{ExitCode: shell.ExitOK, Duration: time.Millisecond}
+	}
+
+	return result, nil
+}
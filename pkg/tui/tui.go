@@ -0,0 +1,137 @@
+// Package tui renders a pipeline's stage lifecycle events as a live-updating
+// bubbletea program, falling back to slog output when stdout isn't a
+// terminal or the frontend is explicitly disabled
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+	"workflow-engine/pkg/pipelines"
+)
+
+// stageRow tracks the latest known state for a single pipeline stage
+type stageRow struct {
+	stage     string
+	status    string
+	err       error
+	startedAt time.Time
+	updatedAt time.Time
+}
+
+// model is the bubbletea Model for the pipeline progress view
+type model struct {
+	events <-chan pipelines.Event
+	rows   map[string]*stageRow
+	order  []string
+	done   bool
+}
+
+// eventMsg wraps a pipelines.Event as a bubbletea Msg
+type eventMsg pipelines.Event
+
+// closedMsg signals that the event channel was closed
+type closedMsg struct{}
+
+func newModel(events <-chan pipelines.Event) model {
+	return model{events: events, rows: make(map[string]*stageRow)}
+}
+
+func (m model) Init() tea.Cmd {
+	return waitForEvent(m.events)
+}
+
+// waitForEvent returns a tea.Cmd that blocks on the next event, translating
+// a closed channel into closedMsg so the program can exit
+func waitForEvent(events <-chan pipelines.Event) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return closedMsg{}
+		}
+		return eventMsg(event)
+	}
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case eventMsg:
+		row, ok := m.rows[msg.Stage]
+		if !ok {
+			row = &stageRow{stage: msg.Stage, startedAt: msg.Timestamp}
+			m.rows[msg.Stage] = row
+			m.order = append(m.order, msg.Stage)
+		}
+		row.status = msg.Status
+		row.err = msg.Err
+		row.updatedAt = msg.Timestamp
+		return m, waitForEvent(m.events)
+	case closedMsg:
+		m.done = true
+		return m, tea.Quit
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "q" {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m model) View() string {
+	// Rendered in m.order (first-seen order) so a row holds a stable
+	// position as its status glyph changes between started/succeeded/failed
+	view := ""
+	for _, stage := range m.order {
+		row := m.rows[stage]
+		symbol := statusSymbol(row.status)
+		elapsed := row.updatedAt.Sub(row.startedAt).Round(time.Millisecond)
+		line := fmt.Sprintf("%s %-24s %8s  %s", symbol, row.stage, elapsed, row.status)
+		if row.err != nil {
+			line += fmt.Sprintf("  (%s)", row.err)
+		}
+		view += line + "\n"
+	}
+	if m.done {
+		view += "\ndone\n"
+	}
+	return view
+}
+
+func statusSymbol(status string) string {
+	switch status {
+	case pipelines.EventStarted:
+		return "…"
+	case pipelines.EventSucceeded:
+		return "✔"
+	case pipelines.EventFailed:
+		return "✘"
+	default:
+		return "?"
+	}
+}
+
+// Run renders events as a live-updating bubbletea program. If stdout isn't a
+// terminal or noTUI is true, it falls back to logging each event via slog
+func Run(events <-chan pipelines.Event, noTUI bool) error {
+	if noTUI || !term.IsTerminal(int(os.Stdout.Fd())) {
+		runFallback(events)
+		return nil
+	}
+
+	program := tea.NewProgram(newModel(events))
+	_, err := program.Run()
+	return err
+}
+
+// runFallback drains events to slog, for non-interactive terminals (CI logs,
+// piped output) where a redrawing TUI would just produce garbage
+func runFallback(events <-chan pipelines.Event) {
+	l := slog.Default().With("pipeline", "progress")
+	for event := range events {
+		l.Info("stage "+event.Status, "stage", event.Stage, "error", event.Err)
+	}
+}